@@ -35,8 +35,12 @@ import (
 )
 
 const (
+	minUploadCutoff     = 50 * 1024 * 1024
 	defaultUploadCutoff = 50 * 1024 * 1024
 	smallFileCutoff     = 15 * 1024 * 1024 // 15 MiB
+	minChunkSize        = 5 * 1024 * 1024  // S3 minimum part size
+	defaultChunkSize    = 16 * 1024 * 1024
+	maxUploadParts      = 10000 // S3 maximum number of parts
 )
 
 func init() {
@@ -70,6 +74,18 @@ func init() {
 			Help:     "Cutoff for switching to multipart upload (>= 50 MiB).",
 			Default:  fs.SizeSuffix(defaultUploadCutoff),
 			Advanced: true,
+		}, {
+			Name: "chunk_size",
+			Help: `Chunk size to use for multipart uploads (>= 5 MiB).
+
+Files of upload_cutoff or larger are uploaded in chunks of this size.
+Each chunk is buffered in memory, and failed chunks are retried
+individually rather than restarting the whole upload.
+
+For files of known size the chunk size is increased automatically
+if needed to stay below the limit of 10,000 parts.`,
+			Default:  fs.SizeSuffix(defaultChunkSize),
+			Advanced: true,
 		}, {
 			Name:     "hard_delete",
 			Help:     "Delete files permanently instead of moving them to trash.",
@@ -90,6 +106,7 @@ func init() {
 // Options defines the configuration for this backend
 type Options struct {
 	UploadCutoff fs.SizeSuffix        `config:"upload_cutoff"`
+	ChunkSize    fs.SizeSuffix        `config:"chunk_size"`
 	Enc          encoder.MultiEncoder `config:"encoding"`
 	AccessToken  string               `config:"access_token"`
 	APIDomain    string               `config:"api_domain"`
@@ -104,6 +121,7 @@ type Fs struct {
 	opt      Options
 	features *fs.Features
 	srv      *rest.Client
+	client   *http.Client // for requests to presigned URLs
 	pacer    *fs.Pacer
 	dirCache *dircache.DirCache
 }
@@ -272,6 +290,38 @@ func (f *Fs) unpadNameForFileJump(name string) string {
 	return name
 }
 
+func checkUploadCutoff(cs fs.SizeSuffix) error {
+	if cs < minUploadCutoff {
+		return fmt.Errorf("%s is less than %s", cs, fs.SizeSuffix(minUploadCutoff))
+	}
+	return nil
+}
+
+func (f *Fs) setUploadCutoff(cs fs.SizeSuffix) (old fs.SizeSuffix, err error) {
+	// The 50 MiB minimum only applies to the config option, tests
+	// need to go down to the S3 minimum part size
+	err = checkUploadChunkSize(cs)
+	if err == nil {
+		old, f.opt.UploadCutoff = f.opt.UploadCutoff, cs
+	}
+	return
+}
+
+func checkUploadChunkSize(cs fs.SizeSuffix) error {
+	if cs < minChunkSize {
+		return fmt.Errorf("%s is less than %s", cs, fs.SizeSuffix(minChunkSize))
+	}
+	return nil
+}
+
+func (f *Fs) setUploadChunkSize(cs fs.SizeSuffix) (old fs.SizeSuffix, err error) {
+	err = checkUploadChunkSize(cs)
+	if err == nil {
+		old, f.opt.ChunkSize = f.opt.ChunkSize, cs
+	}
+	return
+}
+
 // NewFs constructs an Fs from the path, container:path
 func NewFs(ctx context.Context, name, root string, m configmap.Mapper) (fs.Fs, error) {
 	fs.Logf(nil, "WARNING: The FileJump backend is new and experimental. While it can be tested and used, it should not be used for important data or production environments. Please use with caution and ensure you have proper backups.")
@@ -282,15 +332,25 @@ func NewFs(ctx context.Context, name, root string, m configmap.Mapper) (fs.Fs, e
 		return nil, err
 	}
 
+	err = checkUploadCutoff(opt.UploadCutoff)
+	if err != nil {
+		return nil, fmt.Errorf("filejump: upload cutoff: %w", err)
+	}
+	err = checkUploadChunkSize(opt.ChunkSize)
+	if err != nil {
+		return nil, fmt.Errorf("filejump: chunk size: %w", err)
+	}
+
 	root = strings.Trim(root, "/")
 
 	client := fshttp.NewClient(ctx)
 
 	f := &Fs{
-		name: name,
-		root: root,
-		opt:  *opt,
-		srv:  rest.NewClient(client).SetRoot("https://" + opt.APIDomain + "/api/v1"),
+		name:   name,
+		root:   root,
+		opt:    *opt,
+		srv:    rest.NewClient(client).SetRoot("https://" + opt.APIDomain + "/api/v1"),
+		client: client,
 		pacer: fs.NewPacer(ctx, pacer.NewDefault(
 			pacer.MinSleep(10*time.Millisecond),
 			pacer.MaxSleep(2*time.Second),
@@ -306,6 +366,10 @@ func NewFs(ctx context.Context, name, root string, m configmap.Mapper) (fs.Fs, e
 		About:                   f.About,
 		DirCacheFlush:           f.DirCacheFlush,
 	}).Fill(ctx, f)
+	// TUS uploads need the whole file in memory, so streaming isn't possible
+	if opt.APIDomain == "eu.filejump.com" {
+		f.features.PutStream = nil
+	}
 	f.srv.SetHeader("Authorization", "Bearer "+opt.AccessToken)
 
 	// Check workspace ID
@@ -576,16 +640,7 @@ func (f *Fs) PutUnchecked(ctx context.Context, in io.Reader, src fs.ObjectInfo,
 	size := src.Size()
 	modTime := src.ModTime(ctx)
 
-	// For unknown size uploads, we need to read the data first to determine the size
-	if size < 0 {
-		data, err := io.ReadAll(in)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read data for unknown size upload: %w", err)
-		}
-		size = int64(len(data))
-		in = bytes.NewReader(data)
-	}
-
+	// Unknown size uploads are handled by Update
 	o, _, _, err := f.createObject(ctx, remote, modTime, size)
 	if err != nil {
 		return nil, err
@@ -613,16 +668,7 @@ func (f *Fs) Put(ctx context.Context, in io.Reader, src fs.ObjectInfo, options .
 	size := src.Size()
 	modTime := src.ModTime(ctx)
 
-	// For unknown size uploads, we need to read the data first to determine the size
-	if size < 0 {
-		data, err := io.ReadAll(in)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read data for unknown size upload: %w", err)
-		}
-		size = int64(len(data))
-		in = bytes.NewReader(data)
-	}
-
+	// Unknown size uploads are handled by Update
 	o, _, _, err := f.createObject(ctx, remote, modTime, size)
 	if err != nil {
 		return nil, err
@@ -630,6 +676,11 @@ func (f *Fs) Put(ctx context.Context, in io.Reader, src fs.ObjectInfo, options .
 	return o, o.Update(ctx, in, src, options...)
 }
 
+// PutStream uploads to the remote path with the modTime given of indeterminate size
+func (f *Fs) PutStream(ctx context.Context, in io.Reader, src fs.ObjectInfo, options ...fs.OpenOption) (fs.Object, error) {
+	return f.Put(ctx, in, src, options...)
+}
+
 // // Mkdir makes the directory (container, bucket)
 // //
 // // Shouldn't return an error if it already exists
@@ -1117,36 +1168,93 @@ func (f *Fs) Copy(ctx context.Context, src fs.Object, remote string) (fs.Object,
 		return nil, fmt.Errorf("no entries returned from copy operation")
 	}
 
-	// Get the copied entry
+	// Get the copied entry, decoding its name like in listAll
 	copiedEntry := &result.Entries[0]
+	copiedEntry.Name = f.opt.Enc.ToStandardName(f.unpadNameForFileJump(copiedEntry.Name))
 
 	// If the name doesn't match what we want, rename it
 	if copiedEntry.Name != leaf {
-		// Update the entry name via API
-		type RequestUpdate struct {
-			Name string `json:"name"`
+		info, err := f.renameEntry(ctx, copiedEntry.GetID(), copiedEntry.Name, leaf)
+		if err != nil {
+			// Don't leave the copy behind under the server's generated name
+			copiedObj := &Object{fs: f, remote: path.Join(path.Dir(remote), copiedEntry.Name), id: copiedEntry.GetID()}
+			if removeErr := copiedObj.Remove(ctx); removeErr != nil {
+				fs.Errorf(copiedObj, "Failed to remove copy after failed rename: %v", removeErr)
+			}
+			return nil, fmt.Errorf("copy: failed to rename copied file to %q: %w", leaf, err)
 		}
-
-		updateRequest := RequestUpdate{
-			Name: f.padNameForFileJump(leaf),
+		if info != nil {
+			copiedEntry = info
 		}
+	}
 
-		type ResultUpdate struct {
-			Status    string   `json:"status"`
-			FileEntry api.Item `json:"fileEntry"`
-		}
+	// Create new object from the copied entry
+	return f.newObjectWithInfo(ctx, remote, copiedEntry)
+}
 
-		updateResult, err := CallJSON[ResultUpdate, RequestUpdate](ctx, f, "PUT", "/file-entries/"+copiedEntry.GetID(), nil, &updateRequest)
+// moveEntries moves the entries with the given IDs into the directory directoryID
+func (f *Fs) moveEntries(ctx context.Context, entryIDs []int, directoryID string) error {
+	// Destination as *int (nil = Root)
+	var destPtr *int
+	if directoryID != "" && directoryID != "0" {
+		destIDInt, err := strconv.Atoi(directoryID)
 		if err != nil {
-			// If rename fails, we still have a successful copy, just with wrong name
-			fs.Debugf(f, "Failed to rename copied file to %q: %v", leaf, err)
-		} else if updateResult.Status == "success" {
-			copiedEntry = &updateResult.FileEntry
+			return errors.New("invalid destination directory ID")
 		}
+		destPtr = &destIDInt
+	}
+	type RequestMove struct {
+		DestinationID *int  `json:"destinationId"`
+		EntryIDs      []int `json:"entryIds"`
+	}
+	type ResultMove struct {
+		Status string `json:"status"`
+	}
+	request := RequestMove{
+		DestinationID: destPtr,
+		EntryIDs:      entryIDs,
 	}
+	result, err := CallJSONPost[ResultMove, RequestMove](ctx, f, "/file-entries/move", &request)
+	if err != nil {
+		return err
+	}
+	if result.Status != "success" {
+		return errors.New("move failed: " + result.Status)
+	}
+	return nil
+}
 
-	// Create new object from the copied entry
-	return f.newObjectWithInfo(ctx, remote, copiedEntry)
+// renameEntry renames the file or folder entry id from oldLeaf to newLeaf
+//
+// The returned item is only filled in for files.
+func (f *Fs) renameEntry(ctx context.Context, id, oldLeaf, newLeaf string) (*api.Item, error) {
+	type RequestRename struct {
+		Name        string `json:"name"`
+		InitialName string `json:"initialName"`
+	}
+	type ResultRename struct {
+		FileEntry *api.Item `json:"fileEntry"`
+		Status    string    `json:"status"`
+	}
+	request := RequestRename{
+		Name:        f.padNameForFileJump(newLeaf),
+		InitialName: f.padNameForFileJump(oldLeaf),
+	}
+	params := url.Values{}
+	params.Set("_method", "PUT")
+	result, err := CallJSON[ResultRename, RequestRename](ctx, f, "POST", "/file-entries/"+id, &params, &request)
+	if err != nil {
+		return nil, err
+	}
+	if result.Status != "success" {
+		return nil, errors.New("rename failed: " + result.Status)
+	}
+	if result.FileEntry != nil && result.FileEntry.ID != 0 {
+		// Names come back encoded and padded like in listAll
+		result.FileEntry.Name = f.opt.Enc.ToStandardName(f.unpadNameForFileJump(result.FileEntry.Name))
+		return result.FileEntry, nil
+	}
+	return nil, nil
 }
 
 // Move src to this remote using server-side move operations.
@@ -1164,11 +1272,6 @@ func (f *Fs) Move(ctx context.Context, src fs.Object, remote string) (fs.Object,
 		fs.Debugf(src, "Can't move - not same remote type")
 		return nil, fs.ErrorCantMove
 	}
-	srcFs := src.Fs()
-
-	// Defer flushing the cache for the source and destination directories
-	defer f.dirCache.FlushDir(path.Dir(src.Remote()))
-	defer f.dirCache.FlushDir(path.Dir(remote))
 
 	// Read metadata to get the ID and current name
 	err := srcObj.readMetaData(ctx)
@@ -1178,82 +1281,56 @@ func (f *Fs) Move(ctx context.Context, src fs.Object, remote string) (fs.Object,
 	if srcObj.id == "" {
 		return nil, fs.ErrorCantMove
 	}
+	entryIDInt, err := strconv.Atoi(srcObj.id)
+	if err != nil {
+		return nil, errors.New("invalid source file ID")
+	}
 
-	// Find destination directory
+	// Find source directory
+	srcLeaf, srcDirectoryID, err := srcObj.fs.dirCache.FindPath(ctx, srcObj.remote, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find destination directory, creating it if necessary
 	leaf, directoryID, err := f.dirCache.FindPath(ctx, remote, true)
 	if err != nil {
 		return nil, err
 	}
 
+	// FileJump allows duplicate names, so refuse to move over an existing file
+	_, err = f.readMetaDataForPath(ctx, remote)
+	if err == nil {
+		fs.Debugf(src, "Can't move - destination %q already exists", remote)
+		return nil, fs.ErrorCantMove
+	} else if !errors.Is(err, fs.ErrorObjectNotFound) {
+		return nil, err
+	}
+
 	// 1. Move the file to the new directory if needed
-	srcAbsPath := path.Join(srcFs.Root(), srcObj.Remote())
-	dstAbsPath := path.Join(f.Root(), remote)
-	srcAbsDir := path.Dir(srcAbsPath)
-	dstAbsDir := path.Dir(dstAbsPath)
-
-	if srcAbsDir != dstAbsDir {
-		// Destination als *int (nil = Root)
-		var destPtr *int
-		if directoryID != "" && directoryID != "0" {
-			destIDInt, err := strconv.Atoi(directoryID)
-			if err != nil {
-				return nil, errors.New("invalid destination directory ID")
-			}
-			destPtr = &destIDInt
-		}
-		entryIDInt, err := strconv.Atoi(srcObj.id)
-		if err != nil {
-			return nil, errors.New("invalid source file ID")
-		}
-		type RequestMove struct {
-			DestinationID *int  `json:"destinationId"`
-			EntryIDs      []int `json:"entryIds"`
-		}
-		type ResultMove struct {
-			Status string `json:"status"`
-		}
-		request := RequestMove{
-			DestinationID: destPtr,
-			EntryIDs:      []int{entryIDInt},
-		}
-		result, err := CallJSONPost[ResultMove, RequestMove](ctx, f, "/file-entries/move", &request)
+	if srcDirectoryID != directoryID {
+		err = f.moveEntries(ctx, []int{entryIDInt}, directoryID)
 		if err != nil {
 			return nil, err
 		}
-		if result.Status != "success" {
-			return nil, errors.New("move failed: " + result.Status)
-		}
 	}
 
-	// 2. Rename if necessary
-	oldLeaf := path.Base(srcObj.remote)
-	if leaf != oldLeaf {
-		type RequestRename struct {
-			Name        string `json:"name"`
-			InitialName string `json:"initialName"`
-		}
-		type ResultRename struct {
-			FileEntry api.Item `json:"fileEntry"`
-			Status    string   `json:"status"`
-		}
-		request := RequestRename{
-			Name:        f.padNameForFileJump(leaf),
-			InitialName: f.padNameForFileJump(oldLeaf),
-		}
-		params := url.Values{}
-		params.Set("_method", "PUT")
-		result, err := CallJSON[ResultRename, RequestRename](ctx, f, "POST", "/file-entries/"+srcObj.id, &params, &request)
+	// 2. Rename if necessary, moving the file back if that fails
+	var info *api.Item
+	if leaf != srcLeaf {
+		info, err = f.renameEntry(ctx, srcObj.id, srcLeaf, leaf)
 		if err != nil {
+			if srcDirectoryID != directoryID {
+				if moveErr := f.moveEntries(ctx, []int{entryIDInt}, srcDirectoryID); moveErr != nil {
+					fs.Errorf(src, "Failed to move back to source directory after failed rename: %v", moveErr)
+				}
+			}
 			return nil, err
 		}
-		if result.Status != "success" {
-			return nil, errors.New("rename failed: " + result.Status)
-		}
-		return f.newObjectWithInfo(ctx, remote, &result.FileEntry)
 	}
 
-	// Reload metadata and return object
-	return f.newObjectWithInfo(ctx, remote, nil)
+	// Reload metadata if the rename didn't return it and return object
+	return f.newObjectWithInfo(ctx, remote, info)
 }
 
 // DirMove moves src, srcRemote to this remote at dstRemote
@@ -1267,149 +1344,41 @@ func (f *Fs) Move(ctx context.Context, src fs.Object, remote string) (fs.Object,
 func (f *Fs) DirMove(ctx context.Context, src fs.Fs, srcRemote, dstRemote string) error {
 	srcFs, ok := src.(*Fs)
 	if !ok {
-		fs.Debugf(srcFs, "Can't move directory - not same remote type")
+		fs.Debugf(src, "Can't move directory - not same remote type")
 		return fs.ErrorCantDirMove
 	}
 
-	srcID, err := srcFs.dirCache.FindDir(ctx, srcRemote, false)
+	srcID, srcDirectoryID, srcLeaf, dstDirectoryID, dstLeaf, err := f.dirCache.DirMove(ctx, srcFs.dirCache, srcFs.root, srcRemote, f.root, dstRemote)
 	if err != nil {
 		return err
 	}
-
-	// The destination must not already exist.
-	if _, err := f.dirCache.FindDir(ctx, dstRemote, false); err == nil {
-		return fs.ErrorDirExists
-	} else if !errors.Is(err, fs.ErrorDirNotFound) {
-		return err
-	}
-
-	// Special case for moving contents of a directory
-	if dstRemote == "" {
-		dstID, err := f.dirCache.FindDir(ctx, "", true)
-		if err != nil {
-			return fmt.Errorf("DirMove: failed to find destination root: %w", err)
-		}
-
-		var entriesToMove []int
-		_, err = srcFs.listAll(ctx, srcID, false, false, true, func(item *api.Item) bool {
-			id, err := strconv.Atoi(item.GetID())
-			if err == nil {
-				entriesToMove = append(entriesToMove, id)
-			}
-			return false
-		})
-		if err != nil {
-			return fmt.Errorf("DirMove: failed to list source directory %q: %w", srcRemote, err)
-		}
-
-		if len(entriesToMove) > 0 {
-			var destPtr *int
-			if dstID != "" && dstID != "0" {
-				destIDInt, err := strconv.Atoi(dstID)
-				if err != nil {
-					return errors.New("invalid destination directory ID")
-				}
-				destPtr = &destIDInt
-			}
-			type RequestMove struct {
-				DestinationID *int  `json:"destinationId"`
-				EntryIDs      []int `json:"entryIds"`
-			}
-			type ResultMove struct {
-				Status string `json:"status"`
-			}
-			moveReq := RequestMove{
-				DestinationID: destPtr,
-				EntryIDs:      entriesToMove,
-			}
-			_, err = CallJSONPost[ResultMove, RequestMove](ctx, f, "/file-entries/move", &moveReq)
-			if err != nil {
-				return err
-			}
-		}
-
-		err = srcFs.Purge(ctx, srcRemote)
-		if err != nil {
-			return fmt.Errorf("DirMove: failed to purge source directory %q: %w", srcRemote, err)
-		}
-
-		srcFs.dirCache.FlushDir(srcRemote)
-		f.dirCache.FlushDir("")
-		return nil
-	}
-
-	// Normal directory move
-	leafSrc := path.Base(srcRemote)
-	leafDst := path.Base(dstRemote)
-	dstParentRemote := path.Dir(dstRemote)
-	if dstParentRemote == "." {
-		dstParentRemote = ""
-	}
-
-	dstParentID, err := f.dirCache.FindDir(ctx, dstParentRemote, true)
-	if err != nil {
-		return err
-	}
-
 	srcIDInt, err := strconv.Atoi(srcID)
 	if err != nil {
 		return errors.New("invalid source directory ID")
 	}
 
-	var destPtr *int
-	if dstParentID != "" && dstParentID != "0" {
-		destIDInt, err := strconv.Atoi(dstParentID)
+	// Move the folder to the new parent if needed
+	if srcDirectoryID != dstDirectoryID {
+		err = f.moveEntries(ctx, []int{srcIDInt}, dstDirectoryID)
 		if err != nil {
-			return errors.New("invalid destination directory ID")
+			return err
 		}
-		destPtr = &destIDInt
 	}
 
-	type RequestMove struct {
-		DestinationID *int  `json:"destinationId"`
-		EntryIDs      []int `json:"entryIds"`
-	}
-	type ResultMove struct {
-		Status string `json:"status"`
-	}
-	moveReq := RequestMove{
-		DestinationID: destPtr,
-		EntryIDs:      []int{srcIDInt},
-	}
-	_, err = CallJSONPost[ResultMove, RequestMove](ctx, f, "/file-entries/move", &moveReq)
-	if err != nil {
-		return err
-	}
-
-	if leafDst != leafSrc {
-		type RequestRename struct {
-			Name        string `json:"name"`
-			InitialName string `json:"initialName"`
-		}
-		type ResultRename struct {
-			FolderEntry api.Item `json:"folderEntry"`
-			Status      string   `json:"status"`
-		}
-		renameReq := RequestRename{
-			Name:        f.padNameForFileJump(leafDst),
-			InitialName: f.padNameForFileJump(leafSrc),
-		}
-		params := url.Values{}
-		params.Set("_method", "PUT")
-		_, err := CallJSON[ResultRename, RequestRename](
-			ctx, f,
-			"POST",
-			"/file-entries/"+srcID,
-			&params,
-			&renameReq,
-		)
+	// Rename if necessary, moving the folder back if that fails
+	if srcLeaf != dstLeaf {
+		_, err = f.renameEntry(ctx, srcID, srcLeaf, dstLeaf)
 		if err != nil {
+			if srcDirectoryID != dstDirectoryID {
+				if moveErr := f.moveEntries(ctx, []int{srcIDInt}, srcDirectoryID); moveErr != nil {
+					fs.Errorf(src, "Failed to move %q back to source directory after failed rename: %v", srcRemote, moveErr)
+				}
+			}
 			return err
 		}
 	}
 
 	srcFs.dirCache.FlushDir(srcRemote)
-	f.dirCache.FlushDir(dstParentRemote)
 	return nil
 }
 
@@ -1755,13 +1724,27 @@ func (o *Object) Update(ctx context.Context, in io.Reader, src fs.ObjectInfo, op
 	modTime := src.ModTime(ctx)
 
 	// For unknown size uploads, we need to read the data first to determine the size
-	if size < 0 {
+	if size < 0 && o.fs.opt.APIDomain == "eu.filejump.com" {
 		data, err := io.ReadAll(in)
 		if err != nil {
 			return fmt.Errorf("failed to read data for unknown size upload: %w", err)
 		}
 		size = int64(len(data))
 		in = bytes.NewReader(data)
+	} else if size < 0 {
+		// Peek at up to one chunk - if the stream ends before that it
+		// is uploaded in one go, otherwise it is streamed via multipart
+		var buf bytes.Buffer
+		n, err := io.CopyN(&buf, in, int64(o.fs.opt.ChunkSize))
+		switch err {
+		case nil:
+			in = io.MultiReader(&buf, in)
+		case io.EOF:
+			size = n
+			in = &buf
+		default:
+			return fmt.Errorf("failed to read data for unknown size upload: %w", err)
+		}
 	}
 
 	err = o.upload(ctx, in, leaf, directoryID, size, modTime, options...)
@@ -1793,6 +1776,11 @@ func (o *Object) upload(ctx context.Context, in io.Reader, leaf, directoryID str
 		return o.uploadViaTUS(ctx, in, leaf, directoryID, size, modTime, options...)
 	}
 
+	// Large files and streams of unknown size use S3 multipart uploads
+	if size < 0 || size >= int64(o.fs.opt.UploadCutoff) {
+		return o.uploadMultipart(ctx, in, leaf, directoryID, size, modTime, options...)
+	}
+
 	// Default to S3 presigned URL method (drive.filejump.com and others)
 	return o.uploadViaS3(ctx, in, leaf, directoryID, size, modTime, options...)
 }
@@ -1912,7 +1900,7 @@ func (o *Object) uploadViaS3(ctx context.Context, in io.Reader, leaf, directoryI
 	}
 	putReq.Header.Set("Content-Type", "application/octet-stream")
 	putReq.Header.Set("x-amz-acl", resultPresign.ACL)
-	putResp, err := http.DefaultClient.Do(putReq)
+	putResp, err := o.fs.client.Do(putReq)
 	if err != nil {
 		return fmt.Errorf("error uploading file: %w", err)
 	}
@@ -1921,7 +1909,12 @@ func (o *Object) uploadViaS3(ctx context.Context, in io.Reader, leaf, directoryI
 		body, _ := io.ReadAll(putResp.Body)
 		return fmt.Errorf("error uploading file: HTTP %d: %s", putResp.StatusCode, string(body))
 	}
-	// Register file in FileJump
+	return o.createS3Entry(ctx, resultPresign.Key, encodedLeaf, directoryIDInt, workspaceIDInt, size, modTime)
+}
+
+// createS3Entry registers a file uploaded to S3 under key in FileJump
+// and sets the object metadata from the new entry
+func (o *Object) createS3Entry(ctx context.Context, key, encodedLeaf string, directoryIDInt, workspaceIDInt int, size int64, modTime time.Time) (err error) {
 	type RequestEntries struct {
 		WorkspaceID     int         `json:"workspaceId"`
 		ParentID        interface{} `json:"parentId"`
@@ -1945,7 +1938,7 @@ func (o *Object) uploadViaS3(ctx context.Context, in io.Reader, leaf, directoryI
 		Disk:            "uploads",
 		ClientMime:      "application/octet-stream",
 		ClientName:      encodedLeaf,
-		Filename:        path.Base(resultPresign.Key),
+		Filename:        path.Base(key),
 		Size:            size,
 		ClientExtension: "bin",
 	}
@@ -1972,6 +1965,213 @@ func (o *Object) uploadViaS3(ctx context.Context, in io.Reader, leaf, directoryI
 	return nil
 }
 
+// Upload via S3 multipart upload for files >= upload_cutoff
+//
+// If size is -1 the reader is read until EOF.
+func (o *Object) uploadMultipart(ctx context.Context, in io.Reader, leaf, directoryID string, size int64, modTime time.Time, options ...fs.OpenOption) (err error) {
+	directoryIDInt, _ := strconv.Atoi(directoryID)
+	workspaceIDInt, err := strconv.Atoi(o.fs.opt.WorkspaceID)
+	if err != nil {
+		fs.Debugf(o, "Could not parse workspace_id %q, defaulting to 0: %v", o.fs.opt.WorkspaceID, err)
+		workspaceIDInt = 0
+	}
+
+	chunkSize := o.fs.multipartChunkSize(size)
+	if chunkSize != int64(o.fs.opt.ChunkSize) {
+		fs.Debugf(o, "Increasing chunk size to %v to stay below %d parts", fs.SizeSuffix(chunkSize), maxUploadParts)
+	}
+
+	// Create the multipart upload
+	type RequestCreate struct {
+		Filename     string `json:"filename"`
+		Mime         string `json:"mime"`
+		Disk         string `json:"disk"`
+		Size         int64  `json:"size,omitempty"` // omitted if unknown
+		Extension    string `json:"extension"`
+		WorkspaceID  int    `json:"workspaceId"`
+		ParentID     *int   `json:"parentId,omitempty"` // nil for root
+		RelativePath string `json:"relativePath"`
+	}
+	type ResultCreate struct {
+		UploadID string `json:"uploadId"`
+		Key      string `json:"key"`
+		Status   string `json:"status"`
+	}
+	ext, mime := getExtensionAndMime(leaf)
+	encodedLeaf := o.fs.padNameForFileJump(leaf)
+	var parentIDPtr *int
+	if directoryIDInt != 0 {
+		parentIDPtr = &directoryIDInt
+	}
+	requestCreate := RequestCreate{
+		Filename:     encodedLeaf,
+		Mime:         mime,
+		Disk:         "uploads",
+		Size:         max(size, 0),
+		Extension:    ext,
+		WorkspaceID:  workspaceIDInt,
+		ParentID:     parentIDPtr,
+		RelativePath: "",
+	}
+	resultCreate, err := CallJSONPost[ResultCreate, RequestCreate](ctx, o.fs, "/s3/multipart/create", &requestCreate)
+	if err != nil {
+		return fmt.Errorf("error creating multipart upload: %w", err)
+	}
+	if resultCreate.Status != "success" {
+		return fmt.Errorf("error creating multipart upload: status is not 'success'")
+	}
+	if resultCreate.UploadID == "" || resultCreate.Key == "" {
+		return errors.New("error creating multipart upload: no upload ID returned")
+	}
+	key, uploadID := resultCreate.Key, resultCreate.UploadID
+
+	// Abort the multipart upload on failure so no partial upload is left behind
+	defer func() {
+		if err == nil {
+			return
+		}
+		fs.Debugf(o, "Aborting multipart upload: %v", err)
+		type RequestAbort struct {
+			Key      string `json:"key"`
+			UploadID string `json:"uploadId"`
+		}
+		type ResultAbort struct{}
+		_, abortErr := CallJSONPost[ResultAbort, RequestAbort](context.WithoutCancel(ctx), o.fs, "/s3/multipart/abort", &RequestAbort{
+			Key:      key,
+			UploadID: uploadID,
+		})
+		if abortErr != nil {
+			fs.Errorf(o, "Failed to abort multipart upload: %v", abortErr)
+		}
+	}()
+
+	// Upload the parts
+	type Part struct {
+		PartNumber int    `json:"PartNumber"`
+		ETag       string `json:"ETag"`
+	}
+	var (
+		parts []Part
+		total int64
+		buf   []byte
+	)
+	if size > 0 && size < chunkSize {
+		buf = make([]byte, size)
+	} else {
+		buf = make([]byte, chunkSize)
+	}
+	for partNumber := 1; ; partNumber++ {
+		n, readErr := io.ReadFull(in, buf)
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil && readErr != io.ErrUnexpectedEOF {
+			return fmt.Errorf("error reading chunk %d: %w", partNumber, readErr)
+		}
+		// Only streams of unknown size can get here
+		if partNumber > maxUploadParts {
+			return fmt.Errorf("stream is too large for %d parts of %v: increase --filejump-chunk-size", maxUploadParts, fs.SizeSuffix(chunkSize))
+		}
+		etag, err := o.uploadPart(ctx, key, uploadID, partNumber, buf[:n])
+		if err != nil {
+			return fmt.Errorf("error uploading chunk %d: %w", partNumber, err)
+		}
+		parts = append(parts, Part{PartNumber: partNumber, ETag: etag})
+		total += int64(n)
+		if readErr == io.ErrUnexpectedEOF {
+			break
+		}
+	}
+	if size >= 0 && total != size {
+		return fmt.Errorf("multipart upload: uploaded %d bytes but expected %d", total, size)
+	}
+
+	// Complete the multipart upload
+	type RequestComplete struct {
+		Key      string `json:"key"`
+		UploadID string `json:"uploadId"`
+		Parts    []Part `json:"parts"`
+	}
+	type ResultComplete struct {
+		Location string `json:"location"`
+	}
+	requestComplete := RequestComplete{
+		Key:      key,
+		UploadID: uploadID,
+		Parts:    parts,
+	}
+	_, err = CallJSONPost[ResultComplete, RequestComplete](ctx, o.fs, "/s3/multipart/complete", &requestComplete)
+	if err != nil {
+		return fmt.Errorf("error completing multipart upload: %w", err)
+	}
+
+	return o.createS3Entry(ctx, key, encodedLeaf, directoryIDInt, workspaceIDInt, total, modTime)
+}
+
+// multipartChunkSize returns the chunk size to use for a multipart upload
+// of size bytes, increasing chunk_size if necessary to stay within the
+// part limit
+func (f *Fs) multipartChunkSize(size int64) int64 {
+	chunkSize := int64(f.opt.ChunkSize)
+	if size > 0 && size/chunkSize >= maxUploadParts {
+		chunkSize = (size/maxUploadParts + 1024*1024) &^ (1024*1024 - 1)
+	}
+	return chunkSize
+}
+
+// uploadPart uploads a single chunk of a multipart upload, returning its ETag
+//
+// Failed parts are retried via the pacer without restarting the upload.
+func (o *Object) uploadPart(ctx context.Context, key, uploadID string, partNumber int, chunk []byte) (etag string, err error) {
+	type RequestSignParts struct {
+		Key         string `json:"key"`
+		UploadID    string `json:"uploadId"`
+		PartNumbers []int  `json:"partNumbers"`
+	}
+	type ResultSignParts struct {
+		URLs []struct {
+			PartNumber int    `json:"partNumber"`
+			URL        string `json:"url"`
+		} `json:"urls"`
+	}
+	requestSign := RequestSignParts{
+		Key:         key,
+		UploadID:    uploadID,
+		PartNumbers: []int{partNumber},
+	}
+	resultSign, err := CallJSONPost[ResultSignParts, RequestSignParts](ctx, o.fs, "/s3/multipart/batch-sign-part-urls", &requestSign)
+	if err != nil {
+		return "", fmt.Errorf("error requesting presigned part URL: %w", err)
+	}
+	if len(resultSign.URLs) == 0 || resultSign.URLs[0].URL == "" {
+		return "", errors.New("error requesting presigned part URL: no URL returned")
+	}
+	partURL := resultSign.URLs[0].URL
+
+	err = o.fs.pacer.Call(func() (bool, error) {
+		putReq, err := http.NewRequestWithContext(ctx, http.MethodPut, partURL, bytes.NewReader(chunk))
+		if err != nil {
+			return false, fmt.Errorf("error creating PUT request: %w", err)
+		}
+		putReq.ContentLength = int64(len(chunk))
+		putResp, err := o.fs.client.Do(putReq)
+		if err != nil {
+			return shouldRetry(ctx, putResp, err)
+		}
+		defer func() { _ = putResp.Body.Close() }()
+		if putResp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(putResp.Body)
+			return shouldRetry(ctx, putResp, fmt.Errorf("HTTP %d: %s", putResp.StatusCode, string(body)))
+		}
+		etag = putResp.Header.Get("ETag")
+		if etag == "" {
+			return false, errors.New("no ETag returned")
+		}
+		return false, nil
+	})
+	return etag, err
+}
+
 // Perform the actual TUS upload
 func (o *Object) performTUSUpload(ctx context.Context, reader *bytes.Reader, endpoint, fingerprint, encodedName, originalName, ext, mime string, size int64, directoryID string) (string, error) {
 	// Create TUS upload with metadata
@@ -2236,11 +2436,12 @@ func (o *Object) Size() int64 {
 
 // Check the interfaces are satisfied
 var (
-	_ fs.Fs         = (*Fs)(nil)
-	_ fs.Purger     = (*Fs)(nil)
-	_ fs.Copier     = (*Fs)(nil)
-	_ fs.Mover      = (*Fs)(nil)
-	_ fs.DirMover   = (*Fs)(nil)
-	_ fs.CleanUpper = (*Fs)(nil)
-	_ fs.Object     = (*Object)(nil)
+	_ fs.Fs          = (*Fs)(nil)
+	_ fs.Purger      = (*Fs)(nil)
+	_ fs.Copier      = (*Fs)(nil)
+	_ fs.Mover       = (*Fs)(nil)
+	_ fs.DirMover    = (*Fs)(nil)
+	_ fs.CleanUpper  = (*Fs)(nil)
+	_ fs.PutStreamer = (*Fs)(nil)
+	_ fs.Object      = (*Object)(nil)
 )
@@ -0,0 +1,348 @@
+package filejump
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rclone/rclone/fs"
+	"github.com/rclone/rclone/fstest"
+	"github.com/rclone/rclone/fstest/fstests"
+	"github.com/rclone/rclone/lib/pacer"
+	"github.com/rclone/rclone/lib/random"
+	"github.com/rclone/rclone/lib/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUploadCutoff(t *testing.T) {
+	assert.Error(t, checkUploadCutoff(49*fs.Mebi))
+	assert.NoError(t, checkUploadCutoff(50*fs.Mebi))
+	assert.NoError(t, checkUploadCutoff(fs.SizeSuffix(defaultUploadCutoff)))
+}
+
+func TestCheckUploadChunkSize(t *testing.T) {
+	assert.Error(t, checkUploadChunkSize(4*fs.Mebi))
+	assert.NoError(t, checkUploadChunkSize(5*fs.Mebi))
+	assert.NoError(t, checkUploadChunkSize(fs.SizeSuffix(defaultChunkSize)))
+}
+
+func TestMultipartChunkSize(t *testing.T) {
+	f := &Fs{opt: Options{ChunkSize: defaultChunkSize}}
+	for _, test := range []struct {
+		size int64
+		want int64
+	}{
+		{size: -1, want: defaultChunkSize},
+		{size: 0, want: defaultChunkSize},
+		{size: defaultChunkSize * (maxUploadParts - 1), want: defaultChunkSize},
+		{size: defaultChunkSize * maxUploadParts, want: defaultChunkSize + 1024*1024},
+		{size: 1024 * 1024 * 1024 * 1024, want: 105 * 1024 * 1024},
+	} {
+		t.Run(strconv.FormatInt(test.size, 10), func(t *testing.T) {
+			got := f.multipartChunkSize(test.size)
+			assert.Equal(t, test.want, got)
+			if test.size > 0 {
+				assert.Less(t, (test.size+got-1)/got, int64(maxUploadParts+1))
+			}
+		})
+	}
+}
+
+// multipartServer fakes the FileJump multipart upload API and the
+// presigned S3 part URLs
+type multipartServer struct {
+	*httptest.Server
+	mu        sync.Mutex
+	fail      map[int][]int // part number => status codes to return before succeeding
+	puts      map[int]int   // part number => number of PUTs received
+	data      map[int][]byte
+	completed []map[string]any
+	aborted   []string
+	entries   int
+}
+
+func newMultipartServer(t *testing.T) *multipartServer {
+	ms := &multipartServer{
+		fail: map[int][]int{},
+		puts: map[int]int{},
+		data: map[int][]byte{},
+	}
+	mux := http.NewServeMux()
+	reply := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	mux.HandleFunc("POST /s3/multipart/create", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, map[string]string{"uploadId": "upload-id", "key": "uploads/key", "status": "success"})
+	})
+	mux.HandleFunc("POST /s3/multipart/batch-sign-part-urls", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			UploadID    string `json:"uploadId"`
+			PartNumbers []int  `json:"partNumbers"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "upload-id", req.UploadID)
+		var urls []map[string]any
+		for _, n := range req.PartNumbers {
+			urls = append(urls, map[string]any{"partNumber": n, "url": fmt.Sprintf("%s/part/%d", ms.URL, n)})
+		}
+		reply(w, map[string]any{"urls": urls})
+	})
+	mux.HandleFunc("PUT /part/{n}", func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.Atoi(r.PathValue("n"))
+		assert.NoError(t, err)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		ms.mu.Lock()
+		defer ms.mu.Unlock()
+		ms.puts[n]++
+		if codes := ms.fail[n]; len(codes) > 0 {
+			ms.fail[n] = codes[1:]
+			w.WriteHeader(codes[0])
+			return
+		}
+		ms.data[n] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, n))
+	})
+	mux.HandleFunc("POST /s3/multipart/complete", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		ms.completed = append(ms.completed, req)
+		reply(w, map[string]string{"location": "location"})
+	})
+	mux.HandleFunc("POST /s3/multipart/abort", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			UploadID string `json:"uploadId"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		ms.aborted = append(ms.aborted, req.UploadID)
+		reply(w, map[string]string{})
+	})
+	mux.HandleFunc("POST /s3/entries", func(w http.ResponseWriter, r *http.Request) {
+		ms.entries++
+		reply(w, map[string]any{"id": 42, "name": "file.bin", "type": "text"})
+	})
+	ms.Server = httptest.NewServer(mux)
+	t.Cleanup(ms.Close)
+	return ms
+}
+
+// newFs returns an Fs talking to the fake server using chunkSize
+func (ms *multipartServer) newFs(chunkSize fs.SizeSuffix) *Fs {
+	ctx := context.Background()
+	return &Fs{
+		name:   "filejump-test",
+		opt:    Options{ChunkSize: chunkSize, UploadCutoff: defaultUploadCutoff, WorkspaceID: "0"},
+		srv:    rest.NewClient(ms.Client()).SetRoot(ms.URL),
+		client: ms.Client(),
+		pacer: fs.NewPacer(ctx, pacer.NewDefault(
+			pacer.MinSleep(0),
+			pacer.MaxSleep(time.Millisecond),
+		)),
+	}
+}
+
+func TestUploadMultipart(t *testing.T) {
+	ctx := context.Background()
+	contents := random.String(250)
+
+	for _, size := range []int64{int64(len(contents)), -1} {
+		t.Run(fmt.Sprintf("Size%d", size), func(t *testing.T) {
+			ms := newMultipartServer(t)
+			f := ms.newFs(100)
+			o := &Object{fs: f, remote: "file.bin"}
+
+			// Fail the second part once with a retryable error
+			ms.fail[2] = []int{http.StatusInternalServerError}
+
+			err := o.uploadMultipart(ctx, strings.NewReader(contents), "file.bin", "0", size, time.Now())
+			require.NoError(t, err)
+
+			assert.Equal(t, map[int]int{1: 1, 2: 2, 3: 1}, ms.puts)
+			assert.Equal(t, contents, string(bytes.Join([][]byte{ms.data[1], ms.data[2], ms.data[3]}, nil)))
+			require.Len(t, ms.completed, 1)
+			assert.Equal(t, []any{
+				map[string]any{"PartNumber": 1.0, "ETag": `"etag-1"`},
+				map[string]any{"PartNumber": 2.0, "ETag": `"etag-2"`},
+				map[string]any{"PartNumber": 3.0, "ETag": `"etag-3"`},
+			}, ms.completed[0]["parts"])
+			assert.Empty(t, ms.aborted)
+			assert.Equal(t, 1, ms.entries)
+			assert.Equal(t, "42", o.id)
+			assert.Equal(t, int64(len(contents)), o.size)
+		})
+	}
+}
+
+func TestUploadMultipartAbort(t *testing.T) {
+	ctx := context.Background()
+	ms := newMultipartServer(t)
+	f := ms.newFs(100)
+	o := &Object{fs: f, remote: "file.bin"}
+
+	// Fail the second part with an error which isn't retried
+	ms.fail[2] = []int{http.StatusForbidden}
+
+	err := o.uploadMultipart(ctx, strings.NewReader(random.String(250)), "file.bin", "0", 250, time.Now())
+	require.Error(t, err)
+
+	assert.Equal(t, map[int]int{1: 1, 2: 1}, ms.puts)
+	assert.Equal(t, []string{"upload-id"}, ms.aborted)
+	assert.Empty(t, ms.completed)
+	assert.Equal(t, 0, ms.entries)
+}
+
+func TestUploadMultipartTooManyParts(t *testing.T) {
+	ctx := context.Background()
+	ms := newMultipartServer(t)
+	f := ms.newFs(1)
+	o := &Object{fs: f, remote: "file.bin"}
+
+	err := o.uploadMultipart(ctx, strings.NewReader(random.String(maxUploadParts+1)), "file.bin", "0", -1, time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--filejump-chunk-size")
+
+	assert.Len(t, ms.puts, maxUploadParts)
+	assert.Equal(t, []string{"upload-id"}, ms.aborted)
+	assert.Empty(t, ms.completed)
+}
+
+const moveTestDir = "move-test"
+
+// internalTestMove uploads a file to src, moves it server-side to dst
+// and checks it is only to be found at dst afterwards
+func (f *Fs) internalTestMove(t *testing.T, src, dst string) {
+	ctx := context.Background()
+	contents := random.String(100)
+	item := fstest.NewItem(src, contents, fstest.Time("2001-05-06T04:05:06.499999999Z"))
+	obj := fstests.PutTestContents(ctx, t, f, &item, contents, true)
+
+	newObj, err := f.Move(ctx, obj, dst)
+	require.NoError(t, err)
+	assert.Equal(t, dst, newObj.Remote())
+	assert.Equal(t, int64(len(contents)), newObj.Size())
+	assert.Equal(t, obj.(*Object).id, newObj.(*Object).id)
+
+	_, err = f.NewObject(ctx, src)
+	assert.Equal(t, fs.ErrorObjectNotFound, err)
+	got, err := f.NewObject(ctx, dst)
+	require.NoError(t, err)
+	assert.Equal(t, newObj.(*Object).id, got.(*Object).id)
+}
+
+func (f *Fs) InternalTestMove(t *testing.T) {
+	ctx := context.Background()
+	defer func() {
+		assert.NoError(t, f.Purge(ctx, moveTestDir))
+	}()
+
+	t.Run("AcrossDirectories", func(t *testing.T) {
+		require.NoError(t, f.Mkdir(ctx, moveTestDir+"/dst"))
+		f.internalTestMove(t, moveTestDir+"/src/file.txt", moveTestDir+"/dst/file.txt")
+	})
+
+	t.Run("RenameInPlace", func(t *testing.T) {
+		f.internalTestMove(t, moveTestDir+"/rename/old.txt", moveTestDir+"/rename/new.txt")
+	})
+
+	t.Run("NewParent", func(t *testing.T) {
+		f.internalTestMove(t, moveTestDir+"/file.txt", moveTestDir+"/new/parent/moved.txt")
+		_, err := f.dirCache.FindDir(ctx, moveTestDir+"/new/parent", false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("DestinationExists", func(t *testing.T) {
+		contents := random.String(100)
+		srcItem := fstest.NewItem(moveTestDir+"/exists/src.txt", contents, fstest.Time("2001-05-06T04:05:06.499999999Z"))
+		dstItem := fstest.NewItem(moveTestDir+"/exists/dst.txt", contents, fstest.Time("2001-05-06T04:05:06.499999999Z"))
+		obj := fstests.PutTestContents(ctx, t, f, &srcItem, contents, true)
+		_ = fstests.PutTestContents(ctx, t, f, &dstItem, contents, true)
+
+		_, err := f.Move(ctx, obj, dstItem.Path)
+		assert.Equal(t, fs.ErrorCantMove, err)
+	})
+}
+
+func (f *Fs) InternalTestCopy(t *testing.T) {
+	ctx := context.Background()
+	defer func() {
+		assert.NoError(t, f.Purge(ctx, moveTestDir))
+	}()
+
+	contents := random.String(100)
+	item := fstest.NewItem(moveTestDir+"/copy/src.txt", contents, fstest.Time("2001-05-06T04:05:06.499999999Z"))
+	obj := fstests.PutTestContents(ctx, t, f, &item, contents, true)
+
+	for _, dst := range []string{
+		moveTestDir + "/copy/renamed.txt",
+		moveTestDir + "/copy/new/parent/src.txt",
+	} {
+		t.Run(dst, func(t *testing.T) {
+			newObj, err := f.Copy(ctx, obj, dst)
+			require.NoError(t, err)
+			assert.Equal(t, dst, newObj.Remote())
+			assert.Equal(t, int64(len(contents)), newObj.Size())
+			assert.NotEqual(t, obj.(*Object).id, newObj.(*Object).id)
+
+			// The server-side entry must have the requested name
+			got, err := f.NewObject(ctx, dst)
+			require.NoError(t, err)
+			assert.Equal(t, newObj.(*Object).id, got.(*Object).id)
+			_, err = f.NewObject(ctx, item.Path)
+			assert.NoError(t, err)
+		})
+	}
+
+	// The copy into the same directory must not leave a duplicate behind
+	entries, err := f.List(ctx, moveTestDir+"/copy")
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		if _, ok := entry.(fs.Object); ok {
+			names = append(names, entry.Remote())
+		}
+	}
+	assert.ElementsMatch(t, []string{item.Path, moveTestDir + "/copy/renamed.txt"}, names)
+}
+
+func (f *Fs) InternalTestDirMove(t *testing.T) {
+	ctx := context.Background()
+	defer func() {
+		assert.NoError(t, f.Purge(ctx, moveTestDir))
+	}()
+
+	contents := random.String(100)
+	item := fstest.NewItem(moveTestDir+"/dir/sub/file.txt", contents, fstest.Time("2001-05-06T04:05:06.499999999Z"))
+	_ = fstests.PutTestContents(ctx, t, f, &item, contents, true)
+
+	// Move and rename the folder into a parent which doesn't exist yet
+	require.NoError(t, f.DirMove(ctx, f, moveTestDir+"/dir", moveTestDir+"/new/renamed"))
+
+	_, err := f.dirCache.FindDir(ctx, moveTestDir+"/dir", false)
+	assert.Equal(t, fs.ErrorDirNotFound, err)
+	_, err = f.NewObject(ctx, moveTestDir+"/new/renamed/sub/file.txt")
+	assert.NoError(t, err)
+
+	// Moving onto an existing directory must fail
+	require.NoError(t, f.Mkdir(ctx, moveTestDir+"/other"))
+	err = f.DirMove(ctx, f, moveTestDir+"/other", moveTestDir+"/new/renamed")
+	assert.Equal(t, fs.ErrorDirExists, err)
+}
+
+func (f *Fs) InternalTest(t *testing.T) {
+	t.Run("Move", f.InternalTestMove)
+	t.Run("Copy", f.InternalTestCopy)
+	t.Run("DirMove", f.InternalTestDirMove)
+}
+
+var _ fstests.InternalTester = (*Fs)(nil)